@@ -0,0 +1,80 @@
+package gosu
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ApplyUpdate extracts the downloaded release asset natively and atomically
+// swaps it in for the running executable. It replaces the legacy
+// UpdateApp/extractor-script flow.
+func (updater *Updater) ApplyUpdate() UpdateResult {
+	if updater.releaseAsset == nil {
+		panic(errors.New("releaseAsset is nil"))
+	}
+
+	exePath, err := os.Executable()
+	if err != nil {
+		return UpdateResult{
+			Code:    CODE_ERROR,
+			Message: "Unable to locate the running executable.",
+			Details: err.Error(),
+		}
+	}
+
+	newExePath, err := updater.locateNewExecutable(exePath)
+	if err != nil {
+		return UpdateResult{
+			Code:    CODE_ERROR,
+			Message: "Unable to extract the downloaded asset.",
+			Details: err.Error(),
+		}
+	}
+
+	if err := updater.backupExecutable(exePath); err != nil {
+		return UpdateResult{
+			Code:    CODE_ERROR,
+			Message: "Unable to back up the running executable.",
+			Details: err.Error(),
+		}
+	}
+
+	if err := swapExecutable(exePath, newExePath); err != nil {
+		return UpdateResult{
+			Code:    CODE_ERROR,
+			Message: "Unable to replace the running executable.",
+			Details: err.Error(),
+		}
+	}
+
+	logger.Info("Terminating the app")
+	os.Exit(0)
+
+	return UpdateResult{}
+}
+
+// locateNewExecutable returns the new binary to swap in: the downloaded
+// asset itself if it's already a bare executable (e.g. the output of a
+// patch apply), or the matching file extracted from an archive otherwise.
+func (updater *Updater) locateNewExecutable(exePath string) (string, error) {
+	assetName := updater.releaseAsset.Name
+
+	isArchive := strings.HasSuffix(assetName, ".zip") ||
+		strings.HasSuffix(assetName, ".tar.gz") ||
+		strings.HasSuffix(assetName, ".tgz")
+	if !isArchive {
+		return assetName, nil
+	}
+
+	// Extract next to the executable rather than under the system temp dir,
+	// since os.Rename (used by swapExecutable) can't cross filesystems and
+	// the system temp dir is commonly a separate mount (e.g. tmpfs).
+	dir, err := extractArchive(assetName, filepath.Dir(exePath))
+	if err != nil {
+		return "", err
+	}
+
+	return findExecutable(dir, filepath.Base(exePath))
+}
@@ -0,0 +1,58 @@
+package gosu
+
+import (
+	"context"
+	"time"
+)
+
+// AutoUpdatePolicy configures the behavior of a RunAutoUpdate cycle.
+type AutoUpdatePolicy struct {
+	// AutoApply downloads and applies a detected update automatically. When
+	// false, RunAutoUpdate only logs a warning that a new version exists.
+	AutoApply bool
+	// Confirm, when set, is consulted before an auto-applied update is
+	// downloaded; returning false skips that cycle.
+	Confirm func(UpdateResult) bool
+}
+
+// RunAutoUpdate polls CheckUpdates every freq until ctx is cancelled, and on
+// detecting a new version either logs a warning or, per policy, downloads and
+// applies it via DownloadAsset/ApplyUpdate.
+func (updater *Updater) RunAutoUpdate(ctx context.Context, freq time.Duration, policy AutoUpdatePolicy) error {
+	ticker := time.NewTicker(freq)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			updater.runAutoUpdateTick(policy)
+		}
+	}
+}
+
+func (updater *Updater) runAutoUpdateTick(policy AutoUpdatePolicy) {
+	result := updater.CheckUpdates()
+	if result.Code != CODE_NEW_VERSION_DETECTED {
+		return
+	}
+
+	if !policy.AutoApply {
+		logger.Warnf("A new version is available but auto-apply is disabled: %s", result.Message)
+		return
+	}
+
+	if policy.Confirm != nil && !policy.Confirm(result) {
+		logger.Info("Auto-update declined by the confirmation callback")
+		return
+	}
+
+	downloadResult := updater.DownloadAsset(nil)
+	if downloadResult.Code != CODE_DOWNLOADING_COMPLETED {
+		logger.Warnf("Auto-update download failed: %s", downloadResult.Message)
+		return
+	}
+
+	updater.ApplyUpdate()
+}
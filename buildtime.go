@@ -0,0 +1,92 @@
+package gosu
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// buildTimeUnix is populated at build time for callers that ship continuous
+// (nightly/dev) builds rather than tagged SemVer releases, e.g.:
+//
+//	-ldflags "-X github.com/alexandermac/gosu.buildTimeUnix=$(date +%s)"
+//
+// Use ParseBuildTimeUnix to turn BuildTimeUnix() into a time.Time for
+// Updater.BuildTime.
+var buildTimeUnix string
+
+// BuildTimeUnix returns the raw value injected via the buildTimeUnix ldflags
+// convention, or "" if it wasn't set.
+func BuildTimeUnix() string {
+	return buildTimeUnix
+}
+
+// ParseBuildTimeUnix parses a unix-seconds timestamp string, as produced by
+// the buildTimeUnix ldflags convention, into a time.Time.
+func ParseBuildTimeUnix(value string) (time.Time, error) {
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid build time %q: %w", value, err)
+	}
+
+	return time.Unix(seconds, 0), nil
+}
+
+// checkUpdatesByBuildTime is the fallback used by CheckUpdates when the local
+// or remote version can't be parsed as SemVer (e.g. "nightly", "dev"). It
+// compares updater.BuildTime against the release's publish time instead.
+func (updater *Updater) checkUpdatesByBuildTime(lastRelease _GhRelease) UpdateResult {
+	if updater.BuildTime.IsZero() {
+		return UpdateResult{
+			Code:    CODE_ERROR,
+			Message: "Unable to get updates. The SemVer is invalid.",
+		}
+	}
+
+	remoteTime, err := parseReleaseTime(lastRelease)
+	if err != nil {
+		return UpdateResult{
+			Code:    CODE_ERROR,
+			Message: "Unable to get updates. The SemVer is invalid.",
+			Details: err.Error(),
+		}
+	}
+
+	// up-to-date
+	if remoteTime.Equal(updater.BuildTime) {
+		logger.Info("The latest version is already used")
+		return UpdateResult{
+			Code:    CODE_LATEST_VERSION_IS_ALREADY_IN_USE,
+			Message: "You already use the latest version.",
+		}
+	}
+
+	// local build is newer
+	if remoteTime.Before(updater.BuildTime) {
+		logger.Info("The local version is higher than remote")
+		return UpdateResult{
+			Code:    CODE_UNRELEASED_VERSION_IS_IN_USE,
+			Message: "You use the unreleased version.",
+		}
+	}
+
+	// new version detected
+	logger.Infof("New version detected %s", lastRelease.TagName)
+	return UpdateResult{
+		Code: CODE_NEW_VERSION_DETECTED,
+		Message: fmt.Sprintf(
+			"New version detected. Current build is %s, new build is %s. Download update?",
+			updater.BuildTime.Format(time.RFC3339),
+			remoteTime.Format(time.RFC3339),
+		),
+		Details: lastRelease.Body,
+	}
+}
+
+func parseReleaseTime(release _GhRelease) (time.Time, error) {
+	if release.PublishedAt != "" {
+		return time.Parse(time.RFC3339, release.PublishedAt)
+	}
+
+	return time.Parse(time.RFC3339, release.CreatedAt)
+}
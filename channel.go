@@ -0,0 +1,52 @@
+package gosu
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Channel selects which release stream CheckUpdates polls.
+type Channel string
+
+const (
+	// ChannelStable tracks GitHub's "latest" release, the default.
+	ChannelStable Channel = "stable"
+	// ChannelPrerelease tracks the newest release marked as a prerelease.
+	ChannelPrerelease Channel = "prerelease"
+	// ChannelNightly tracks the newest release whose tag starts with TagPrefix.
+	ChannelNightly Channel = "nightly"
+)
+
+// getLastReleaseForChannel fetches the repo's release list (only the first
+// page the API returns, newest first) and picks the newest one matching
+// updater.Channel (and updater.TagPrefix for ChannelNightly), since
+// /releases/latest only ever returns the newest non-prerelease release.
+func (updater *Updater) getLastReleaseForChannel() (_GhRelease, error) {
+	releasesUrl := fmt.Sprintf("https://api.github.com/repos/%s/releases", updater.orgRepoName)
+
+	var releases []_GhRelease
+	res, err := getHttpClient(updater.GhAccessToken).
+		R().
+		SetResult(&releases).
+		Get(releasesUrl)
+	if err != nil {
+		return _GhRelease{}, err
+	}
+	if err := checkHttpResponse(res); err != nil {
+		return _GhRelease{}, err
+	}
+
+	for _, release := range releases {
+		if updater.Channel == ChannelPrerelease && !release.Prerelease {
+			continue
+		}
+		if updater.Channel == ChannelNightly && updater.TagPrefix != "" && !strings.HasPrefix(release.TagName, updater.TagPrefix) {
+			continue
+		}
+
+		logger.Debugf("The last release for channel %s: %v has been gotten successfully", updater.Channel, release)
+		return release, nil
+	}
+
+	return _GhRelease{}, fmt.Errorf("no release found for channel %q", updater.Channel)
+}
@@ -0,0 +1,63 @@
+package gosu
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSafeJoin(t *testing.T) {
+	destDir := filepath.Join(string(os.PathSeparator), "tmp", "gosu-extract-abc")
+
+	tests := []struct {
+		name    string
+		entry   string
+		wantErr bool
+	}{
+		{"plain file", "app-linux", false},
+		{"nested path", "bin/app-linux", false},
+		{"parent traversal", "../evil", true},
+		{"nested parent traversal", "bin/../../evil", true},
+		{"absolute path is rejoined under destDir", "/etc/passwd", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, err := safeJoin(destDir, tt.entry)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("safeJoin(%q, %q) = %q, nil; want an error", destDir, tt.entry, path)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("safeJoin(%q, %q) returned error: %v", destDir, tt.entry, err)
+			}
+		})
+	}
+}
+
+func TestFindExecutable(t *testing.T) {
+	dir := t.TempDir()
+
+	nestedDir := filepath.Join(dir, "app-1.2.3", "bin")
+	if err := os.MkdirAll(nestedDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	execPath := filepath.Join(nestedDir, "app")
+	if err := os.WriteFile(execPath, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := findExecutable(dir, "app")
+	if err != nil {
+		t.Fatalf("findExecutable returned error: %v", err)
+	}
+	if found != execPath {
+		t.Errorf("findExecutable = %q, want %q", found, execPath)
+	}
+
+	if _, err := findExecutable(dir, "missing"); err == nil {
+		t.Error("findExecutable(missing) = nil error, want an error")
+	}
+}
@@ -5,9 +5,11 @@ import (
 	_ "embed"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
 
 	"golang.org/x/exp/slices"
@@ -32,19 +34,32 @@ const (
 	// DownloadAsset
 	CODE_DOWNLOADING_CANCELLED = iota
 	CODE_DOWNLOADING_COMPLETED = iota
+	CODE_VERIFICATION_FAILED   = iota
 	CODE_ERROR                 = iota
 )
 
 type Updater struct {
-	ReleasesUrl       string
-	ChangelogUrl      string
-	LocalVersion      string
-	GhAccessToken     string
-	DownloadChangelog bool
-	lastRelease       *_GhRelease
-	releaseAsset      *_GhReleaseAsset
-	downloadingCtx    context.Context
-	cancelDownloading context.CancelFunc
+	ReleasesUrl          string
+	ChangelogUrl         string
+	LocalVersion         string
+	GhAccessToken        string
+	DownloadChangelog    bool
+	ChecksumAssetSuffix  string
+	SignatureAssetSuffix string
+	Ed25519PublicKey     []byte
+	PatchMode            PatchMode
+	Channel              Channel
+	TagPrefix            string
+	BuildTime            time.Time
+	Source               ReleaseSource
+	BackupDir            string
+	RollbackGracePeriod  time.Duration
+	orgRepoName          string
+	lastRelease          *_GhRelease
+	releaseAsset         *_GhReleaseAsset
+	downloadingCtx       context.Context
+	cancelDownloading    context.CancelFunc
+	mu                   sync.Mutex
 }
 
 type UpdateResult struct {
@@ -60,10 +75,12 @@ type DownloadingProgress struct {
 }
 
 type _GhRelease struct {
-	TagName   string            `json:"tag_name"`
-	CreatedAt string            `json:"created_at"`
-	Assets    []_GhReleaseAsset `json:"assets"`
-	Body      string            `json:"body"`
+	TagName     string            `json:"tag_name"`
+	CreatedAt   string            `json:"created_at"`
+	PublishedAt string            `json:"published_at"`
+	Prerelease  bool              `json:"prerelease"`
+	Assets      []_GhReleaseAsset `json:"assets"`
+	Body        string            `json:"body"`
 }
 
 type _GhReleaseAsset struct {
@@ -74,18 +91,31 @@ type _GhReleaseAsset struct {
 	updateScriptBody string
 }
 
-func New(orgRepoName, ghAccessToken, localVersion string) *Updater {
+// New returns an Updater for the given "org/repo" GitHub repository. It
+// defaults to ChannelStable; pass a channel to track prerelease or nightly
+// releases instead (see Channel and TagPrefix).
+func New(orgRepoName, ghAccessToken, localVersion string, channel ...Channel) *Updater {
+	ch := ChannelStable
+	if len(channel) > 0 {
+		ch = channel[0]
+	}
+
 	return &Updater{
 		ReleasesUrl:   fmt.Sprintf("https://api.github.com/repos/%s/releases/latest", orgRepoName),
 		ChangelogUrl:  fmt.Sprintf("https://api.github.com/repos/%s/contents/CHANGELOG.md", orgRepoName),
 		LocalVersion:  localVersion,
 		GhAccessToken: ghAccessToken,
+		Channel:       ch,
+		orgRepoName:   orgRepoName,
 	}
 }
 
 func (updater *Updater) CheckUpdates() UpdateResult {
 	logger.Info("Checking for updates")
 
+	updater.mu.Lock()
+	defer updater.mu.Unlock()
+
 	lastRelease, err := updater.getLastRelease()
 	if err != nil {
 		return UpdateResult{
@@ -100,10 +130,7 @@ func (updater *Updater) CheckUpdates() UpdateResult {
 	localSemver := parseSemVer(updater.LocalVersion)
 
 	if remoteSemver == nil || localSemver == nil {
-		return UpdateResult{
-			Code:    CODE_ERROR,
-			Message: "Unable to get updates. The SemVer is invalid.",
-		}
+		return updater.checkUpdatesByBuildTime(lastRelease)
 	}
 
 	// up-to-date
@@ -151,6 +178,9 @@ func (updater *Updater) DownloadAsset(progressCh chan<- DownloadingProgress) Upd
 		panic(errors.New("lastRelease is nil"))
 	}
 
+	updater.mu.Lock()
+	defer updater.mu.Unlock()
+
 	stopCh := make(chan bool)
 	defer func() {
 		if progressCh != nil {
@@ -163,27 +193,47 @@ func (updater *Updater) DownloadAsset(progressCh chan<- DownloadingProgress) Upd
 	updater.downloadingCtx = ctx
 	updater.cancelDownloading = cancel
 
-	var asset _GhReleaseAsset
+	var osSuffix, scriptName, scriptBody string
 	if strings.Contains(runtime.GOOS, "linux") {
-		assetIndex := slices.IndexFunc(updater.lastRelease.Assets, func(a _GhReleaseAsset) bool {
-			return strings.Contains(a.Name, "-linux")
-		})
-		asset = updater.lastRelease.Assets[assetIndex]
-		asset.updateScriptName = _LINUX_SCRIPT_NAME
-		asset.updateScriptBody = linuxScript
+		osSuffix = "-linux"
+		scriptName = _LINUX_SCRIPT_NAME
+		scriptBody = linuxScript
 	} else if strings.Contains(runtime.GOOS, "windows") {
-		assetIndex := slices.IndexFunc(updater.lastRelease.Assets, func(a _GhReleaseAsset) bool {
-			return strings.Contains(a.Name, "-win")
-		})
-		asset = updater.lastRelease.Assets[assetIndex]
-		asset.updateScriptName = _WIN_SCRIPT_NAME
-		asset.updateScriptBody = windowsScript
+		osSuffix = "-win"
+		scriptName = _WIN_SCRIPT_NAME
+		scriptBody = windowsScript
 	} else {
 		return UpdateResult{
 			Code:    CODE_ERROR,
 			Message: "Unsupported OS: " + runtime.GOOS,
 		}
 	}
+
+	if updater.PatchMode != PatchModeNever {
+		patchedAsset, patched, err := updater.downloadPatch(osSuffix, scriptName, scriptBody)
+		if err != nil {
+			return UpdateResult{
+				Code:    CODE_ERROR,
+				Message: "Unable to apply the patch.",
+				Details: err.Error(),
+			}
+		}
+		if patched {
+			updater.releaseAsset = patchedAsset
+			return UpdateResult{
+				Code:    CODE_DOWNLOADING_COMPLETED,
+				Message: "A new application version downloaded successfully. Restart to complete update?",
+			}
+		}
+	}
+
+	var asset _GhReleaseAsset
+	assetIndex := slices.IndexFunc(updater.lastRelease.Assets, func(a _GhReleaseAsset) bool {
+		return strings.Contains(a.Name, osSuffix)
+	})
+	asset = updater.lastRelease.Assets[assetIndex]
+	asset.updateScriptName = scriptName
+	asset.updateScriptBody = scriptBody
 	updater.releaseAsset = &asset
 
 	err := removeFile(asset.Name)
@@ -214,6 +264,15 @@ func (updater *Updater) DownloadAsset(progressCh chan<- DownloadingProgress) Upd
 		}
 	}
 
+	if err := updater.verifyAsset(&asset); err != nil {
+		removeFile(asset.Name)
+		return UpdateResult{
+			Code:    CODE_VERIFICATION_FAILED,
+			Message: "Unable to verify the downloaded asset.",
+			Details: err.Error(),
+		}
+	}
+
 	if progressCh != nil {
 		progressCh <- DownloadingProgress{
 			TotalSize:       asset.Size,
@@ -237,6 +296,12 @@ func (updater *Updater) CancelAssetDownloading() {
 	updater.cancelDownloading()
 }
 
+// UpdateApp writes the embedded scripts/linux.txt or scripts/windows.txt to
+// the working directory as update.sh/update.cmd, starts it and exits. Those
+// scripts unpack the fixed archive name ppm-linux.tar/ppm-win.tar and start
+// ./ppm; they don't refer to the downloaded asset by its own name. It's kept
+// as a legacy opt-in; new callers should prefer
+// ApplyUpdate, which extracts and swaps the binary natively in Go.
 func (updater *Updater) UpdateApp() UpdateResult {
 	if updater.releaseAsset == nil {
 		panic(errors.New("releaseAsset is nil"))
@@ -260,6 +325,21 @@ func (updater *Updater) UpdateApp() UpdateResult {
 func (updater *Updater) getLastRelease() (_GhRelease, error) {
 	logger.Debug("Getting the last release")
 
+	if updater.Source != nil {
+		release, err := updater.Source.LatestRelease(context.Background())
+		if err != nil {
+			return _GhRelease{}, err
+		}
+
+		ghRelease := releaseFromSource(*release)
+		logger.Debugf("The last release: %v has been gotten successfully", ghRelease)
+		return ghRelease, nil
+	}
+
+	if updater.Channel != "" && updater.Channel != ChannelStable {
+		return updater.getLastReleaseForChannel()
+	}
+
 	var ghRelease _GhRelease
 	res, err := getHttpClient(updater.GhAccessToken).
 		R().
@@ -279,6 +359,10 @@ func (updater *Updater) getLastRelease() (_GhRelease, error) {
 func (updater *Updater) getChangelog() (string, error) {
 	logger.Debug("Getting the changelog")
 
+	if updater.Source != nil {
+		return updater.Source.Changelog(context.Background())
+	}
+
 	res, err := getHttpClient(updater.GhAccessToken).
 		R().
 		SetHeader("Accept", "application/vnd.github.raw").
@@ -297,6 +381,27 @@ func (updater *Updater) getChangelog() (string, error) {
 func (updater *Updater) downloadAsset(asset *_GhReleaseAsset) error {
 	logger.Debugf("Downloading the asset %s from %s", asset.Name, asset.Url)
 
+	if updater.Source != nil {
+		body, err := updater.Source.Download(updater.downloadingCtx, asset.Url)
+		if err != nil {
+			return err
+		}
+		defer body.Close()
+
+		file, err := os.Create(asset.Name)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		if _, err := io.Copy(file, body); err != nil {
+			return err
+		}
+
+		logger.Debugf("The asset %s has been downloaded successfully", asset.Name)
+		return nil
+	}
+
 	res, err := getHttpClient(updater.GhAccessToken).
 		SetRetryCount(3).
 		SetTimeout(time.Minute).
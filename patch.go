@@ -0,0 +1,163 @@
+package gosu
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gabstv/go-bsdiff/pkg/bspatch"
+	"golang.org/x/exp/slices"
+)
+
+type PatchMode int
+
+const (
+	// PatchModeAuto downloads a patch asset when one is published for the
+	// currently installed version and falls back to the full asset otherwise.
+	PatchModeAuto PatchMode = iota
+	// PatchModeAlways requires a patch asset to exist; DownloadAsset fails
+	// instead of falling back to the full asset when none is found or the
+	// patch can't be applied.
+	PatchModeAlways
+	// PatchModeNever always downloads the full release asset.
+	PatchModeNever
+)
+
+// downloadPatch looks for a differential update asset matching the locally
+// installed version and, if found, downloads and applies it against the
+// running executable. It reports patched=false (with a nil error) whenever
+// DownloadAsset should fall back to downloading the full asset instead.
+func (updater *Updater) downloadPatch(osSuffix, scriptName, scriptBody string) (asset *_GhReleaseAsset, patched bool, err error) {
+	patchName := fmt.Sprintf("gosu-%s-to-%s%s.patch", updater.LocalVersion, updater.lastRelease.TagName, osSuffix)
+	assetIndex := slices.IndexFunc(updater.lastRelease.Assets, func(a _GhReleaseAsset) bool {
+		return a.Name == patchName
+	})
+	if assetIndex == -1 {
+		if updater.PatchMode == PatchModeAlways {
+			return nil, false, fmt.Errorf("no patch asset %s published for this release", patchName)
+		}
+		return nil, false, nil
+	}
+	patchAsset := updater.lastRelease.Assets[assetIndex]
+
+	patchedPath, err := updater.applyPatch(&patchAsset, osSuffix)
+	if err != nil {
+		if updater.PatchMode == PatchModeAlways {
+			return nil, false, err
+		}
+		logger.Warnf("Unable to apply the patch %s, falling back to the full asset, error: %s", patchAsset.Name, err.Error())
+		return nil, false, nil
+	}
+
+	patchAsset.Name = patchedPath
+	patchAsset.updateScriptName = scriptName
+	patchAsset.updateScriptBody = scriptBody
+	return &patchAsset, true, nil
+}
+
+// applyPatch downloads a bsdiff patch asset and applies it against the
+// currently running executable, producing a new executable as a temporary
+// file in the executable's directory. The resulting file path is returned in place of a downloaded
+// full asset. If ChecksumAssetSuffix is configured and the release also
+// publishes a matching full asset for osSuffix, the patched result is
+// verified against that asset's published checksum before being returned,
+// since a stale or mismatched oldBytes silently produces a corrupt binary.
+func (updater *Updater) applyPatch(patchAsset *_GhReleaseAsset, osSuffix string) (string, error) {
+	logger.Debugf("Downloading the patch %s from %s", patchAsset.Name, patchAsset.Url)
+
+	if err := updater.downloadAsset(patchAsset); err != nil {
+		return "", err
+	}
+	defer removeFile(patchAsset.Name)
+
+	if err := updater.verifyAsset(patchAsset); err != nil {
+		return "", err
+	}
+
+	exePath, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+
+	oldBytes, err := os.ReadFile(exePath)
+	if err != nil {
+		return "", err
+	}
+
+	patchBytes, err := os.ReadFile(patchAsset.Name)
+	if err != nil {
+		return "", err
+	}
+
+	newBytes, err := bspatch.Bytes(oldBytes, patchBytes)
+	if err != nil {
+		return "", fmt.Errorf("unable to apply the patch: %w", err)
+	}
+
+	// Same directory as exePath so the later swapExecutable rename doesn't cross filesystems.
+	tmpFile, err := os.CreateTemp(filepath.Dir(exePath), "gosu-patched-*")
+	if err != nil {
+		return "", err
+	}
+	defer tmpFile.Close()
+
+	if _, err := tmpFile.Write(newBytes); err != nil {
+		return "", err
+	}
+	if err := os.Chmod(tmpFile.Name(), 0755); err != nil {
+		return "", err
+	}
+
+	if err := updater.verifyPatchResult(tmpFile.Name(), osSuffix); err != nil {
+		removeFile(tmpFile.Name())
+		return "", err
+	}
+
+	logger.Debugf("The patch %s has been applied successfully", patchAsset.Name)
+	return tmpFile.Name(), nil
+}
+
+// verifyPatchResult checks patchedPath against the checksum published for
+// the full release asset matching osSuffix, when ChecksumAssetSuffix is
+// configured and such an asset exists. It's a no-op otherwise, since there's
+// nothing to verify the patch result against.
+func (updater *Updater) verifyPatchResult(patchedPath, osSuffix string) error {
+	if updater.ChecksumAssetSuffix == "" {
+		return nil
+	}
+
+	assetIndex := slices.IndexFunc(updater.lastRelease.Assets, func(a _GhReleaseAsset) bool {
+		return strings.Contains(a.Name, osSuffix)
+	})
+	if assetIndex == -1 {
+		return nil
+	}
+	fullAsset := updater.lastRelease.Assets[assetIndex]
+
+	sumsAsset, err := updater.findCompanionAsset(fullAsset.Name + updater.ChecksumAssetSuffix)
+	if err != nil {
+		return nil
+	}
+
+	sumsBody, err := updater.downloadAssetBody(sumsAsset)
+	if err != nil {
+		return fmt.Errorf("unable to download the checksum asset: %w", err)
+	}
+
+	expectedDigest, err := findChecksumLine(string(sumsBody), fullAsset.Name)
+	if err != nil {
+		return err
+	}
+
+	actualDigest, err := sha256File(patchedPath)
+	if err != nil {
+		return err
+	}
+
+	if !strings.EqualFold(expectedDigest, actualDigest) {
+		return fmt.Errorf("the patched executable's checksum doesn't match %s: expected %s, got %s", fullAsset.Name, expectedDigest, actualDigest)
+	}
+
+	return nil
+}
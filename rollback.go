@@ -0,0 +1,218 @@
+package gosu
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+)
+
+const (
+	_STATE_FILE_NAME               = "gosu-state.json"
+	_DEFAULT_ROLLBACK_GRACE_PERIOD = 30 * time.Second
+)
+
+type _UpdateState struct {
+	PreviousVersion string `json:"previousVersion"`
+	BackupPath      string `json:"backupPath"`
+}
+
+// Rollback restores the executable backed up by the last ApplyUpdate call
+// and relaunches it, terminating the current (broken) process.
+func (updater *Updater) Rollback() UpdateResult {
+	exePath, err := os.Executable()
+	if err != nil {
+		return UpdateResult{
+			Code:    CODE_ERROR,
+			Message: "Unable to locate the running executable.",
+			Details: err.Error(),
+		}
+	}
+
+	state, err := updater.loadState(exePath)
+	if err != nil {
+		return UpdateResult{
+			Code:    CODE_ERROR,
+			Message: "Unable to read the rollback state.",
+			Details: err.Error(),
+		}
+	}
+
+	logger.Infof("Rolling back to version %s", state.PreviousVersion)
+
+	// BackupPath may be on a different filesystem (see backupPath), so copy it
+	// next to exePath first rather than handing it straight to swapExecutable.
+	restoredPath := filepath.Join(filepath.Dir(exePath), filepath.Base(exePath)+".restored")
+	if err := copyFile(state.BackupPath, restoredPath, 0755); err != nil {
+		return UpdateResult{
+			Code:    CODE_ERROR,
+			Message: "Unable to restore the previous executable.",
+			Details: err.Error(),
+		}
+	}
+
+	if err := swapExecutable(exePath, restoredPath); err != nil {
+		return UpdateResult{
+			Code:    CODE_ERROR,
+			Message: "Unable to restore the previous executable.",
+			Details: err.Error(),
+		}
+	}
+
+	if err := relaunch(exePath); err != nil {
+		// The executable on disk is already restored, but nothing relaunched
+		// to pick it up - leave the rollback state in place so a later retry
+		// (manual or via VerifyAfterUpdate) can still find the backup.
+		return UpdateResult{
+			Code:    CODE_ERROR,
+			Message: "Unable to relaunch after rollback.",
+			Details: err.Error(),
+		}
+	}
+
+	updater.clearState(exePath)
+	removeFile(state.BackupPath)
+
+	logger.Info("Terminating the app")
+	os.Exit(0)
+
+	return UpdateResult{}
+}
+
+// VerifyAfterUpdate calls probe on every startup after ApplyUpdate left a
+// pending rollback state behind; it's a no-op on normal runs, since there is
+// no state to verify. If probe keeps failing once updater.RollbackGracePeriod
+// (default 30s) elapses, it automatically calls Rollback. Callers should
+// invoke this early in main(), before starting any long-running work.
+func (updater *Updater) VerifyAfterUpdate(probe func() error) {
+	exePath, err := os.Executable()
+	if err != nil {
+		logger.Error(err)
+		return
+	}
+
+	state, err := updater.loadState(exePath)
+	if err != nil {
+		return
+	}
+
+	grace := updater.RollbackGracePeriod
+	if grace == 0 {
+		grace = _DEFAULT_ROLLBACK_GRACE_PERIOD
+	}
+
+	var lastErr error
+	deadline := time.Now().Add(grace)
+	for time.Now().Before(deadline) {
+		if lastErr = probe(); lastErr == nil {
+			logger.Info("The new version passed the post-update probe")
+			updater.clearState(exePath)
+			removeFile(state.BackupPath)
+			return
+		}
+		time.Sleep(time.Second)
+	}
+
+	logger.Warnf("The new version failed the post-update probe, rolling back. Last error: %s", lastErr.Error())
+	updater.Rollback()
+}
+
+func (updater *Updater) backupPath(exePath string) string {
+	if updater.BackupDir != "" {
+		return filepath.Join(updater.BackupDir, filepath.Base(exePath)+".bak")
+	}
+
+	return exePath + ".bak"
+}
+
+func (updater *Updater) statePath(exePath string) string {
+	return filepath.Join(filepath.Dir(exePath), _STATE_FILE_NAME)
+}
+
+func (updater *Updater) backupExecutable(exePath string) error {
+	backupPath := updater.backupPath(exePath)
+
+	if dir := filepath.Dir(backupPath); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+	}
+
+	if err := copyFile(exePath, backupPath, 0755); err != nil {
+		return err
+	}
+
+	state := _UpdateState{
+		PreviousVersion: updater.LocalVersion,
+		BackupPath:      backupPath,
+	}
+	stateBytes, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	logger.Debugf("Backed up the executable to %s", backupPath)
+	return os.WriteFile(updater.statePath(exePath), stateBytes, 0644)
+}
+
+func (updater *Updater) loadState(exePath string) (_UpdateState, error) {
+	data, err := os.ReadFile(updater.statePath(exePath))
+	if err != nil {
+		return _UpdateState{}, err
+	}
+
+	var state _UpdateState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return _UpdateState{}, err
+	}
+
+	return state, nil
+}
+
+func (updater *Updater) clearState(exePath string) {
+	if err := removeFile(updater.statePath(exePath)); err != nil {
+		logger.Warnf("Unable to clear the rollback state, error: %s", err.Error())
+	}
+}
+
+func relaunch(exePath string) error {
+	cmd := exec.Command(exePath, os.Args[1:]...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Stdin = os.Stdin
+
+	return cmd.Start()
+}
+
+// copyFile streams srcPath to dstPath, writing through a temp file in
+// dstPath's directory and renaming it into place so a crash or full disk
+// mid-copy can't leave a truncated file at dstPath.
+func copyFile(srcPath, dstPath string, mode os.FileMode) error {
+	src, err := os.Open(srcPath)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	tmp, err := os.CreateTemp(filepath.Dir(dstPath), filepath.Base(dstPath)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, src); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(mode); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), dstPath)
+}
@@ -0,0 +1,57 @@
+package gosu
+
+import (
+	"context"
+	"io"
+)
+
+// Release is the source-agnostic representation of a release fetched by a
+// ReleaseSource. It mirrors the _GhRelease/_GhReleaseAsset shape so the rest
+// of Updater (asset selection, verification, patching) doesn't need to know
+// which provider produced it.
+type Release struct {
+	TagName     string  `json:"tagName"`
+	PublishedAt string  `json:"publishedAt"`
+	Prerelease  bool    `json:"prerelease"`
+	Body        string  `json:"body"`
+	Assets      []Asset `json:"assets"`
+}
+
+// Asset is the source-agnostic representation of a single release asset.
+type Asset struct {
+	Name string `json:"name"`
+	Url  string `json:"url"`
+	Size int    `json:"size"`
+}
+
+// ReleaseSource abstracts fetching the latest release and its changelog from
+// a specific hosting provider, so Updater isn't hard-coded to the GitHub
+// REST API. Implementations ship in the gosu/sources/* subpackages.
+type ReleaseSource interface {
+	LatestRelease(ctx context.Context) (*Release, error)
+	Changelog(ctx context.Context) (string, error)
+	// Download opens a Release/Asset Url returned by this same source, using
+	// this source's own client and credentials.
+	Download(ctx context.Context, url string) (io.ReadCloser, error)
+}
+
+// releaseFromSource normalizes a Release fetched through updater.Source into
+// the internal _GhRelease shape used by the rest of Updater.
+func releaseFromSource(release Release) _GhRelease {
+	assets := make([]_GhReleaseAsset, len(release.Assets))
+	for i, asset := range release.Assets {
+		assets[i] = _GhReleaseAsset{
+			Name: asset.Name,
+			Url:  asset.Url,
+			Size: asset.Size,
+		}
+	}
+
+	return _GhRelease{
+		TagName:     release.TagName,
+		PublishedAt: release.PublishedAt,
+		Prerelease:  release.Prerelease,
+		Body:        release.Body,
+		Assets:      assets,
+	}
+}
@@ -0,0 +1,84 @@
+// Package generic implements gosu.ReleaseSource by fetching a small JSON
+// manifest from a user-provided URL, so private or self-hosted build
+// servers can drive the updater without an OAuth token.
+package generic
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/go-resty/resty/v2"
+
+	"github.com/alexandermac/gosu"
+)
+
+// Source fetches a release manifest from ManifestUrl. The manifest is
+// expected to be a JSON document shaped like gosu.Release, e.g.:
+//
+//	{
+//	  "tagName": "v1.2.3",
+//	  "publishedAt": "2026-07-29T12:00:00Z",
+//	  "body": "## Changes\n...",
+//	  "assets": [{"name": "app-linux", "url": "https://build.example.com/app-linux", "size": 1234}]
+//	}
+type Source struct {
+	ManifestUrl string
+	// AuthHeader, when set, is sent verbatim as the Authorization header,
+	// e.g. "Bearer <token>".
+	AuthHeader string
+}
+
+// New returns a generic ReleaseSource reading the manifest at manifestUrl.
+func New(manifestUrl, authHeader string) *Source {
+	return &Source{
+		ManifestUrl: manifestUrl,
+		AuthHeader:  authHeader,
+	}
+}
+
+func (s *Source) LatestRelease(ctx context.Context) (*gosu.Release, error) {
+	var release gosu.Release
+
+	req := resty.New().R().SetContext(ctx).SetResult(&release)
+	if s.AuthHeader != "" {
+		req.SetHeader("Authorization", s.AuthHeader)
+	}
+
+	res, err := req.Get(s.ManifestUrl)
+	if err != nil {
+		return nil, err
+	}
+	if res.IsError() {
+		return nil, fmt.Errorf("request failed with status code: %d. %s", res.StatusCode(), res.String())
+	}
+
+	return &release, nil
+}
+
+func (s *Source) Changelog(ctx context.Context) (string, error) {
+	release, err := s.LatestRelease(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return release.Body, nil
+}
+
+func (s *Source) Download(ctx context.Context, url string) (io.ReadCloser, error) {
+	req := resty.New().R().SetContext(ctx).SetDoNotParseResponse(true)
+	if s.AuthHeader != "" {
+		req.SetHeader("Authorization", s.AuthHeader)
+	}
+
+	res, err := req.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	if res.IsError() {
+		defer res.RawBody().Close()
+		return nil, fmt.Errorf("request failed with status code: %d", res.StatusCode())
+	}
+
+	return res.RawBody(), nil
+}
@@ -0,0 +1,108 @@
+// Package gitea implements gosu.ReleaseSource against the Gitea REST API.
+package gitea
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/go-resty/resty/v2"
+
+	"github.com/alexandermac/gosu"
+)
+
+// Source fetches releases from a Gitea repository's releases API.
+type Source struct {
+	// BaseUrl is the Gitea instance root, e.g. "https://gitea.example.com".
+	BaseUrl     string
+	OrgRepoName string
+	AccessToken string
+}
+
+// New returns a Gitea ReleaseSource for the given instance and "owner/repo".
+func New(baseUrl, orgRepoName, accessToken string) *Source {
+	return &Source{
+		BaseUrl:     baseUrl,
+		OrgRepoName: orgRepoName,
+		AccessToken: accessToken,
+	}
+}
+
+func (s *Source) LatestRelease(ctx context.Context) (*gosu.Release, error) {
+	url := fmt.Sprintf("%s/api/v1/repos/%s/releases/latest", s.BaseUrl, s.OrgRepoName)
+
+	var release gtRelease
+	res, err := s.client().R().SetContext(ctx).SetResult(&release).Get(url)
+	if err != nil {
+		return nil, err
+	}
+	if res.IsError() {
+		return nil, fmt.Errorf("request failed with status code: %d. %s", res.StatusCode(), res.String())
+	}
+
+	return release.toRelease(), nil
+}
+
+func (s *Source) Changelog(ctx context.Context) (string, error) {
+	release, err := s.LatestRelease(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return release.Body, nil
+}
+
+func (s *Source) Download(ctx context.Context, url string) (io.ReadCloser, error) {
+	res, err := s.client().R().SetContext(ctx).SetDoNotParseResponse(true).Get(url)
+	if err != nil {
+		return nil, err
+	}
+	if res.IsError() {
+		defer res.RawBody().Close()
+		return nil, fmt.Errorf("request failed with status code: %d", res.StatusCode())
+	}
+
+	return res.RawBody(), nil
+}
+
+func (s *Source) client() *resty.Client {
+	client := resty.New()
+	if s.AccessToken != "" {
+		client.Header.Set("Authorization", fmt.Sprintf("token %s", s.AccessToken))
+	}
+
+	return client
+}
+
+type gtAsset struct {
+	Name string `json:"name"`
+	Url  string `json:"browser_download_url"`
+	Size int    `json:"size"`
+}
+
+type gtRelease struct {
+	TagName     string    `json:"tag_name"`
+	PublishedAt string    `json:"published_at"`
+	Prerelease  bool      `json:"prerelease"`
+	Body        string    `json:"body"`
+	Assets      []gtAsset `json:"assets"`
+}
+
+func (r gtRelease) toRelease() *gosu.Release {
+	assets := make([]gosu.Asset, len(r.Assets))
+	for i, asset := range r.Assets {
+		assets[i] = gosu.Asset{
+			Name: asset.Name,
+			Url:  asset.Url,
+			Size: asset.Size,
+		}
+	}
+
+	return &gosu.Release{
+		TagName:     r.TagName,
+		PublishedAt: r.PublishedAt,
+		Prerelease:  r.Prerelease,
+		Body:        r.Body,
+		Assets:      assets,
+	}
+}
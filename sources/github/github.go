@@ -0,0 +1,117 @@
+// Package github implements gosu.ReleaseSource against the GitHub REST API.
+// Updater.GhAccessToken/ReleasesUrl/ChangelogUrl cover the no-Source-configured
+// case internally (to avoid an import cycle), so this package is only wired
+// in when a caller explicitly sets Updater.Source = github.New(...); it's
+// also usable standalone for release fetching without the rest of Updater.
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/go-resty/resty/v2"
+
+	"github.com/alexandermac/gosu"
+)
+
+// Source fetches releases and changelogs from a GitHub repository.
+type Source struct {
+	OrgRepoName string
+	AccessToken string
+}
+
+// New returns a GitHub ReleaseSource for the given "org/repo" and an optional
+// access token (required for private repositories).
+func New(orgRepoName, accessToken string) *Source {
+	return &Source{
+		OrgRepoName: orgRepoName,
+		AccessToken: accessToken,
+	}
+}
+
+func (s *Source) LatestRelease(ctx context.Context) (*gosu.Release, error) {
+	url := fmt.Sprintf("https://api.github.com/repos/%s/releases/latest", s.OrgRepoName)
+
+	var release ghRelease
+	res, err := s.client().R().SetContext(ctx).SetResult(&release).Get(url)
+	if err != nil {
+		return nil, err
+	}
+	if res.IsError() {
+		return nil, fmt.Errorf("request failed with status code: %d. %s", res.StatusCode(), res.String())
+	}
+
+	return release.toRelease(), nil
+}
+
+func (s *Source) Changelog(ctx context.Context) (string, error) {
+	url := fmt.Sprintf("https://api.github.com/repos/%s/contents/CHANGELOG.md", s.OrgRepoName)
+
+	res, err := s.client().R().SetContext(ctx).SetHeader("Accept", "application/vnd.github.raw").Get(url)
+	if err != nil {
+		return "", err
+	}
+	if res.IsError() {
+		return "", fmt.Errorf("request failed with status code: %d. %s", res.StatusCode(), res.String())
+	}
+
+	return res.String(), nil
+}
+
+func (s *Source) Download(ctx context.Context, url string) (io.ReadCloser, error) {
+	res, err := s.client().R().SetContext(ctx).SetHeader("Accept", "application/octet-stream").SetDoNotParseResponse(true).Get(url)
+	if err != nil {
+		return nil, err
+	}
+	if res.IsError() {
+		defer res.RawBody().Close()
+		return nil, fmt.Errorf("request failed with status code: %d", res.StatusCode())
+	}
+
+	return res.RawBody(), nil
+}
+
+func (s *Source) client() *resty.Client {
+	client := resty.New()
+	client.Header.Set("Accept", "application/vnd.github+json")
+	client.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if s.AccessToken != "" {
+		client.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.AccessToken))
+	}
+
+	return client
+}
+
+type ghReleaseAsset struct {
+	Name string `json:"name"`
+	Url  string `json:"url"`
+	Size int    `json:"size"`
+}
+
+type ghRelease struct {
+	TagName     string           `json:"tag_name"`
+	PublishedAt string           `json:"published_at"`
+	Prerelease  bool             `json:"prerelease"`
+	Body        string           `json:"body"`
+	Assets      []ghReleaseAsset `json:"assets"`
+}
+
+func (r ghRelease) toRelease() *gosu.Release {
+	assets := make([]gosu.Asset, len(r.Assets))
+	for i, asset := range r.Assets {
+		assets[i] = gosu.Asset{
+			Name: asset.Name,
+			Url:  asset.Url,
+			Size: asset.Size,
+		}
+	}
+
+	return &gosu.Release{
+		TagName:     r.TagName,
+		PublishedAt: r.PublishedAt,
+		Prerelease:  r.Prerelease,
+		Body:        r.Body,
+		Assets:      assets,
+	}
+}
@@ -0,0 +1,116 @@
+// Package gitlab implements gosu.ReleaseSource against the GitLab REST API.
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/go-resty/resty/v2"
+
+	"github.com/alexandermac/gosu"
+)
+
+// Source fetches releases from a GitLab project's releases API.
+type Source struct {
+	// BaseUrl is the GitLab instance root, e.g. "https://gitlab.com".
+	BaseUrl string
+	// ProjectId is the numeric project id, or a URL-encoded
+	// "namespace%2Fproject" path.
+	ProjectId   string
+	AccessToken string
+}
+
+// New returns a GitLab ReleaseSource for the given instance and project.
+func New(baseUrl, projectId, accessToken string) *Source {
+	return &Source{
+		BaseUrl:     baseUrl,
+		ProjectId:   projectId,
+		AccessToken: accessToken,
+	}
+}
+
+func (s *Source) LatestRelease(ctx context.Context) (*gosu.Release, error) {
+	url := fmt.Sprintf("%s/api/v4/projects/%s/releases", s.BaseUrl, s.ProjectId)
+
+	var releases []glRelease
+	res, err := s.client().R().SetContext(ctx).SetResult(&releases).Get(url)
+	if err != nil {
+		return nil, err
+	}
+	if res.IsError() {
+		return nil, fmt.Errorf("request failed with status code: %d. %s", res.StatusCode(), res.String())
+	}
+	if len(releases) == 0 {
+		return nil, fmt.Errorf("no releases found for project %s", s.ProjectId)
+	}
+
+	// GitLab returns releases ordered by released_at descending.
+	return releases[0].toRelease(), nil
+}
+
+func (s *Source) Changelog(ctx context.Context) (string, error) {
+	release, err := s.LatestRelease(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return release.Body, nil
+}
+
+func (s *Source) Download(ctx context.Context, url string) (io.ReadCloser, error) {
+	res, err := s.client().R().SetContext(ctx).SetDoNotParseResponse(true).Get(url)
+	if err != nil {
+		return nil, err
+	}
+	if res.IsError() {
+		defer res.RawBody().Close()
+		return nil, fmt.Errorf("request failed with status code: %d", res.StatusCode())
+	}
+
+	return res.RawBody(), nil
+}
+
+func (s *Source) client() *resty.Client {
+	client := resty.New()
+	if s.AccessToken != "" {
+		client.Header.Set("PRIVATE-TOKEN", s.AccessToken)
+	}
+
+	return client
+}
+
+type glAssetLink struct {
+	Name string `json:"name"`
+	Url  string `json:"direct_asset_url"`
+}
+
+type glAssets struct {
+	Links []glAssetLink `json:"links"`
+}
+
+type glRelease struct {
+	TagName         string   `json:"tag_name"`
+	ReleasedAt      string   `json:"released_at"`
+	UpcomingRelease bool     `json:"upcoming_release"`
+	Description     string   `json:"description"`
+	Assets          glAssets `json:"assets"`
+}
+
+func (r glRelease) toRelease() *gosu.Release {
+	assets := make([]gosu.Asset, len(r.Assets.Links))
+	for i, link := range r.Assets.Links {
+		assets[i] = gosu.Asset{
+			Name: link.Name,
+			Url:  link.Url,
+		}
+	}
+
+	return &gosu.Release{
+		TagName:     r.TagName,
+		PublishedAt: r.ReleasedAt,
+		Prerelease:  r.UpcomingRelease,
+		Body:        r.Description,
+		Assets:      assets,
+	}
+}
@@ -0,0 +1,20 @@
+package gosu
+
+import "os"
+
+// swapExecutable atomically replaces oldPath with newPath, keeping the
+// previous binary alongside as oldPath+".old". Windows has permitted
+// renaming a running executable since Vista (the loader opens it with
+// FILE_SHARE_DELETE), so the same synchronous rename-rename works on every
+// platform; no deferred-to-reboot or helper-process trickery is needed.
+func swapExecutable(oldPath, newPath string) error {
+	if err := os.Rename(oldPath, oldPath+".old"); err != nil {
+		return err
+	}
+
+	if err := os.Rename(newPath, oldPath); err != nil {
+		return err
+	}
+
+	return os.Chmod(oldPath, 0755)
+}
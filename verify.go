@@ -0,0 +1,158 @@
+package gosu
+
+import (
+	"bufio"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/exp/slices"
+)
+
+// verifyAsset is a no-op unless ChecksumAssetSuffix/SignatureAssetSuffix are configured.
+func (updater *Updater) verifyAsset(asset *_GhReleaseAsset) error {
+	if updater.ChecksumAssetSuffix != "" {
+		if err := updater.verifyChecksum(asset); err != nil {
+			return err
+		}
+	}
+
+	if len(updater.Ed25519PublicKey) > 0 && updater.SignatureAssetSuffix != "" {
+		if err := updater.verifySignature(asset); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (updater *Updater) verifyChecksum(asset *_GhReleaseAsset) error {
+	logger.Debugf("Verifying the checksum of the asset %s", asset.Name)
+
+	sumsAsset, err := updater.findCompanionAsset(asset.Name + updater.ChecksumAssetSuffix)
+	if err != nil {
+		return err
+	}
+
+	sumsBody, err := updater.downloadAssetBody(sumsAsset)
+	if err != nil {
+		return fmt.Errorf("unable to download the checksum asset: %w", err)
+	}
+
+	expectedDigest, err := findChecksumLine(string(sumsBody), asset.Name)
+	if err != nil {
+		return err
+	}
+
+	actualDigest, err := sha256File(asset.Name)
+	if err != nil {
+		return err
+	}
+
+	if !strings.EqualFold(expectedDigest, actualDigest) {
+		return fmt.Errorf("checksum mismatch for %s: expected %s, got %s", asset.Name, expectedDigest, actualDigest)
+	}
+
+	logger.Debugf("The checksum of the asset %s is valid", asset.Name)
+	return nil
+}
+
+func (updater *Updater) verifySignature(asset *_GhReleaseAsset) error {
+	logger.Debugf("Verifying the signature of the asset %s", asset.Name)
+
+	sigAsset, err := updater.findCompanionAsset(asset.Name + updater.SignatureAssetSuffix)
+	if err != nil {
+		return err
+	}
+
+	signature, err := updater.downloadAssetBody(sigAsset)
+	if err != nil {
+		return fmt.Errorf("unable to download the signature asset: %w", err)
+	}
+
+	assetBody, err := os.ReadFile(asset.Name)
+	if err != nil {
+		return err
+	}
+
+	if !ed25519.Verify(ed25519.PublicKey(updater.Ed25519PublicKey), assetBody, signature) {
+		return fmt.Errorf("signature verification failed for %s", asset.Name)
+	}
+
+	logger.Debugf("The signature of the asset %s is valid", asset.Name)
+	return nil
+}
+
+func (updater *Updater) findCompanionAsset(name string) (_GhReleaseAsset, error) {
+	assetIndex := slices.IndexFunc(updater.lastRelease.Assets, func(a _GhReleaseAsset) bool {
+		return a.Name == name
+	})
+	if assetIndex == -1 {
+		return _GhReleaseAsset{}, fmt.Errorf("companion asset %s not found in the release", name)
+	}
+
+	return updater.lastRelease.Assets[assetIndex], nil
+}
+
+func (updater *Updater) downloadAssetBody(asset _GhReleaseAsset) ([]byte, error) {
+	if updater.Source != nil {
+		body, err := updater.Source.Download(context.Background(), asset.Url)
+		if err != nil {
+			return nil, err
+		}
+		defer body.Close()
+
+		return io.ReadAll(body)
+	}
+
+	res, err := getHttpClient(updater.GhAccessToken).
+		R().
+		SetHeader("Accept", "application/octet-stream").
+		Get(asset.Url)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkHttpResponse(res); err != nil {
+		return nil, err
+	}
+
+	return res.Body(), nil
+}
+
+// findChecksumLine looks up assetName in a SHA256SUMS-style listing
+// ("<hex digest>  <file name>" per line) and returns its digest.
+func findChecksumLine(sums string, assetName string) (string, error) {
+	scanner := bufio.NewScanner(strings.NewReader(sums))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		name := strings.TrimPrefix(fields[1], "*")
+		if name == assetName || strings.HasSuffix(name, "/"+assetName) {
+			return fields[0], nil
+		}
+	}
+
+	return "", fmt.Errorf("checksum for %s not found in the sums file", assetName)
+}
+
+func sha256File(fileName string) (string, error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, file); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
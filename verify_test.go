@@ -0,0 +1,53 @@
+package gosu
+
+import "testing"
+
+func TestFindChecksumLine(t *testing.T) {
+	const sums = `1111111111111111111111111111111111111111111111111111111111111111  app-linux
+2222222222222222222222222222222222222222222222222222222222222222 *app-win.exe
+3333333333333333333333333333333333333333333333333333333333333333  dist/app-darwin
+`
+
+	tests := []struct {
+		name      string
+		assetName string
+		wantSum   string
+		wantErr   bool
+	}{
+		{"plain entry", "app-linux", "1111111111111111111111111111111111111111111111111111111111111111", false},
+		{"binary-marker prefix is stripped", "app-win.exe", "2222222222222222222222222222222222222222222222222222222222222222", false},
+		{"entry nested in a directory", "dist/app-darwin", "3333333333333333333333333333333333333333333333333333333333333333", false},
+		{"matched by suffix against a directory entry", "app-darwin", "3333333333333333333333333333333333333333333333333333333333333333", false},
+		{"not found", "app-missing", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sum, err := findChecksumLine(sums, tt.assetName)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("findChecksumLine(%q) = %q, nil; want an error", tt.assetName, sum)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("findChecksumLine(%q) returned error: %v", tt.assetName, err)
+			}
+			if sum != tt.wantSum {
+				t.Errorf("findChecksumLine(%q) = %q, want %q", tt.assetName, sum, tt.wantSum)
+			}
+		})
+	}
+}
+
+func TestFindChecksumLineIgnoresMalformedLines(t *testing.T) {
+	const sums = "not a valid line\n\naaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa  app-linux\n"
+
+	sum, err := findChecksumLine(sums, "app-linux")
+	if err != nil {
+		t.Fatalf("findChecksumLine returned error: %v", err)
+	}
+	if sum != "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa" {
+		t.Errorf("findChecksumLine = %q, want the app-linux digest", sum)
+	}
+}